@@ -0,0 +1,4 @@
+// +k8s:deepcopy-gen=package,register
+// +groupName=operator.openshift.io
+// Package v1 contains API Schema definitions for the operator v1 API group.
+package v1
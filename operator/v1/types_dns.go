@@ -0,0 +1,475 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DNS manages the CoreDNS component to provide a name resolution service
+// for pods and services in the cluster.
+//
+// This supports the DNS Operator's management of the upstream forwarding
+// configuration used to resolve names outside of the cluster domain.
+//
+// Compatibility level 1: Stable within a major release for a minimum of 12 months or 3 minor releases (whichever is longer).
+// +openshift:compatibility-gen:level=1
+type DNS struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec is the specification of the desired behavior of the DNS.
+	Spec DNSSpec `json:"spec,omitempty"`
+	// status is the most recently observed status of the DNS.
+	Status DNSStatus `json:"status,omitempty"`
+}
+
+// DNSSpec is the specification of the desired behavior of the DNS.
+type DNSSpec struct {
+	// upstreamResolvers defines a schema for configuring CoreDNS
+	// upstream resolvers used to resolve names not covered by the cluster
+	// domain. If this field is not specified, the default upstream is
+	// the one inherited from the node's /etc/resolv.conf.
+	// +optional
+	UpstreamResolvers UpstreamResolvers `json:"upstreamResolvers,omitempty"`
+
+	// nodePlacement provides explicit control over the scheduling of DNS
+	// pods.
+	//
+	// Generally, it is useful to run a DNS pod on every node so that DNS
+	// queries are always handled by a local DNS pod instead of going over
+	// the network to a DNS pod on another node. However, security policy
+	// may require restricting the placement of DNS pods to specific nodes.
+	// For example, if security policy prohibits pods on arbitrary nodes
+	// from communicating with the API, a node selector can be specified to
+	// restrict DNS pods to nodes that are permitted to communicate with
+	// the API. Conversely, if running DNS pods on nodes with a particular
+	// taint is desired, a toleration can be specified for that taint.
+	//
+	// If unset, defaults are used. See nodePlacement for more details.
+	//
+	// +optional
+	NodePlacement DNSNodePlacement `json:"nodePlacement,omitempty"`
+
+	// managementState indicates whether the DNS operator should manage
+	// cluster DNS.
+	// +optional
+	ManagementState ManagementState `json:"managementState,omitempty"`
+
+	// resolvePrivateEnabled indicates whether PTR, SOA, and NS queries for
+	// private address space (RFC 1918, link-local, ULA, and any subnets
+	// listed in PrivateSubnets) should be routed to PrivateResolvers
+	// instead of the top-level UpstreamResolvers. When true, either
+	// PrivateResolvers or a non-empty PrivateSubnets must be set.
+	//
+	// +optional
+	ResolvePrivateEnabled *bool `json:"resolvePrivateEnabled,omitempty"`
+
+	// privateResolvers, when ResolvePrivateEnabled is true, is used to
+	// resolve PTR, SOA, and NS queries for private address space instead
+	// of the top-level UpstreamResolvers. This mirrors the split between
+	// public upstreams and private PTR resolvers supported by other
+	// recursive DNS servers.
+	//
+	// +optional
+	PrivateResolvers *UpstreamResolvers `json:"privateResolvers,omitempty"`
+
+	// privateSubnets is a list of CIDRs, in addition to the well-known
+	// private ranges (RFC 1918, fe80::/10 link-local, and ULA), whose PTR,
+	// SOA, and NS queries are routed to PrivateResolvers when
+	// ResolvePrivateEnabled is true. Both IPv4 and IPv6 CIDRs are
+	// supported, including zero-length prefixes such as "10.0.0.0/0".
+	//
+	// +optional
+	PrivateSubnets []string `json:"privateSubnets,omitempty"`
+
+	// caching configures TTL bounds, negative caching, and optimistic
+	// (stale-while-revalidate) serving for the CoreDNS cache plugin. If
+	// unset, CoreDNS defaults are used.
+	//
+	// +optional
+	Caching *CachingPolicy `json:"caching,omitempty"`
+
+	// protection configures query-handling protections such as per-client
+	// rate limiting, EDNS Client Subnet, DNSSEC validation, and refusal of
+	// ANY queries. If unset, CoreDNS defaults are used.
+	//
+	// +optional
+	Protection *ProtectionSpec `json:"protection,omitempty"`
+}
+
+// ProtectionSpec configures protections CoreDNS applies to incoming and
+// outgoing queries.
+type ProtectionSpec struct {
+	// rateLimitQPS is the maximum number of queries per second accepted
+	// from a single client, aggregated by RateLimitSubnetLenV4/V6. A value
+	// of 0 disables rate limiting. Defaults to 0.
+	//
+	// +optional
+	RateLimitQPS *uint32 `json:"rateLimitQPS,omitempty"`
+
+	// rateLimitSubnetLenV4 is the IPv4 mask length used to aggregate
+	// clients into rate-limit buckets. Defaults to 24.
+	//
+	// +kubebuilder:validation:Maximum=32
+	// +optional
+	RateLimitSubnetLenV4 *uint32 `json:"rateLimitSubnetLenV4,omitempty"`
+
+	// rateLimitSubnetLenV6 is the IPv6 mask length used to aggregate
+	// clients into rate-limit buckets. Defaults to 56.
+	//
+	// +kubebuilder:validation:Maximum=128
+	// +optional
+	RateLimitSubnetLenV6 *uint32 `json:"rateLimitSubnetLenV6,omitempty"`
+
+	// ednsClientSubnet, when true, adds an EDNS Client Subnet option to
+	// queries forwarded upstream so that the upstream can tailor its
+	// answer to the client's network location. It cannot be enabled when
+	// any upstream is SystemResolvConf, because the system resolver path
+	// cannot guarantee ECS propagation.
+	//
+	// +optional
+	EDNSClientSubnet *bool `json:"ednsClientSubnet,omitempty"`
+
+	// dnssecValidation, when true, validates DNSSEC signatures on answers
+	// received from upstream and returns SERVFAIL for answers that fail
+	// validation.
+	//
+	// +optional
+	DNSSECValidation *bool `json:"dnssecValidation,omitempty"`
+
+	// refuseANY, when true, refuses queries of type ANY with REFUSED
+	// instead of forwarding them upstream.
+	//
+	// +optional
+	RefuseANY *bool `json:"refuseANY,omitempty"`
+}
+
+// CachingPolicy configures CoreDNS's response cache.
+type CachingPolicy struct {
+	// minTTLSeconds is a lower bound on the TTL of positive (successful)
+	// responses kept in the cache, overriding any smaller TTL returned by
+	// the upstream. It must be less than or equal to MaxTTLSeconds.
+	// Defaults to 0 (no lower bound).
+	//
+	// +kubebuilder:validation:Maximum=86400
+	// +optional
+	MinTTLSeconds *uint32 `json:"minTTLSeconds,omitempty"`
+
+	// maxTTLSeconds is an upper bound on the TTL of positive (successful)
+	// responses kept in the cache, overriding any larger TTL returned by
+	// the upstream. An upper bound of 24h is enforced to catch
+	// configuration typos. Defaults to 3600.
+	//
+	// +kubebuilder:validation:Maximum=86400
+	// +optional
+	MaxTTLSeconds *uint32 `json:"maxTTLSeconds,omitempty"`
+
+	// maxNegativeTTLSeconds is an upper bound on the TTL of negative
+	// (NXDOMAIN/NODATA) responses kept in the cache. An upper bound of 24h
+	// is enforced to catch configuration typos. Defaults to 3600.
+	//
+	// +kubebuilder:validation:Maximum=86400
+	// +optional
+	MaxNegativeTTLSeconds *uint32 `json:"maxNegativeTTLSeconds,omitempty"`
+
+	// sizeMiB is the maximum size of the response cache, in mebibytes.
+	// Defaults to a CoreDNS-chosen value.
+	//
+	// +optional
+	SizeMiB *uint32 `json:"sizeMiB,omitempty"`
+
+	// optimistic, when true, serves stale-but-expired cache entries
+	// immediately while asynchronously refreshing them from upstream,
+	// instead of blocking the client on the refresh. It requires
+	// MaxTTLSeconds to be non-zero, since an entry that is never cached
+	// cannot be served stale.
+	//
+	// +optional
+	Optimistic *bool `json:"optimistic,omitempty"`
+}
+
+// DNSNodePlacement describes the node scheduling configuration for DNS pods.
+type DNSNodePlacement struct {
+	// nodeSelector is the node selector applied to DNS pods.
+	//
+	// If empty, the default is used, which is currently the following:
+	//
+	//   kubernetes.io/os: linux
+	//
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// tolerations is a list of tolerations applied to DNS pods.
+	//
+	// If empty, the DNS operator sets a toleration for the
+	// "node-role.kubernetes.io/master" taint. This default is subject to
+	// change. Specifying tolerations without including a toleration for
+	// the "node-role.kubernetes.io/master" taint may be risky as it could
+	// lead to an outage if all worker nodes become unavailable.
+	//
+	// +optional
+	Tolerations []Toleration `json:"tolerations,omitempty"`
+}
+
+// ForwardingPolicy is the policy to use when forwarding a request to more
+// than one upstream.
+//
+// +kubebuilder:validation:Enum=Random;RoundRobin;Sequential;LoadBalance;Fastest;Parallel
+type ForwardingPolicy string
+
+const (
+	// RoundRobinForwardingPolicy picks upstreams in round-robin order, moving
+	// to the next upstream after each request.
+	RoundRobinForwardingPolicy ForwardingPolicy = "RoundRobin"
+	// LoadBalanceForwardingPolicy selects an upstream at random, weighted
+	// by Weight and biased toward upstreams that have historically
+	// answered fastest. The controller publishes the per-upstream latency
+	// signal it bases this bias on as a Prometheus metric on DNS status.
+	LoadBalanceForwardingPolicy ForwardingPolicy = "LoadBalance"
+	// FastestForwardingPolicy queries all upstreams in parallel, returns
+	// the first successful non-error answer, and cancels the rest.
+	FastestForwardingPolicy ForwardingPolicy = "Fastest"
+	// ParallelForwardingPolicy queries all upstreams in parallel and
+	// merges the answers, without the latency-based ranking that
+	// LoadBalance and Fastest use.
+	ParallelForwardingPolicy ForwardingPolicy = "Parallel"
+)
+
+// UpstreamType is a type of upstream resolver.
+//
+// +kubebuilder:validation:Enum="";SystemResolvConf;Network;TLS;HTTPS;QUIC
+type UpstreamType string
+
+const (
+	// SystemResolveConfType is the upstream type which indicates we are
+	// using the system's /etc/resolv.conf for upstream resolvers.
+	SystemResolveConfType UpstreamType = "SystemResolvConf"
+	// NetworkResolverType is the upstream type which indicates we are using
+	// a network resolver, meaning the upstream has an Address and Port.
+	NetworkResolverType UpstreamType = "Network"
+	// TLSResolverType is the upstream type for DNS-over-TLS (DoT), dialed
+	// using an Address/Port pair and verified against ServerName.
+	TLSResolverType UpstreamType = "TLS"
+	// HTTPSResolverType is the upstream type for DNS-over-HTTPS (DoH),
+	// queried against the DoH endpoint given in URL.
+	HTTPSResolverType UpstreamType = "HTTPS"
+	// QUICResolverType is the upstream type for DNS-over-QUIC (DoQ), dialed
+	// using an Address/Port pair and verified against ServerName.
+	QUICResolverType UpstreamType = "QUIC"
+)
+
+// Upstream can be of type SystemResolvConf, Network, TLS, HTTPS, or QUIC.
+//
+// - For the SystemResolvConf type, no further fields are required.
+// - For the Network type, Address is required; Port may optionally be
+//   specified.
+// - For the TLS and QUIC types, Address and ServerName are required; Port
+//   may optionally be specified.
+// - For the HTTPS type, URL is required instead of Address/Port.
+//
+// The API itself does not substitute a value when Port is left unset; the
+// DNS operator applies its own default of 53 for Network and 853 for TLS
+// and QUIC when reconciling the resource.
+type Upstream struct {
+	// Type defines whether this upstream contains an IP/IP:port resolver or
+	// the local /etc/resolv.conf.
+	//
+	// +unionDiscriminator
+	// +optional
+	Type UpstreamType `json:"type,omitempty"`
+
+	// Address must be defined when Type is set to Network, TLS, or QUIC. It
+	// will be ignored otherwise. It must be a valid IPv4 or IPv6 address.
+	//
+	// +optional
+	Address string `json:"address,omitempty"`
+
+	// Port may be defined when Type is set to Network, TLS, or QUIC. It
+	// will be ignored otherwise. Port must be between 1 and 65535. If
+	// unspecified, it is left at its zero value here; the DNS operator
+	// defaults it to 53 for Network and 853 for TLS and QUIC when
+	// reconciling the resource.
+	//
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Port uint32 `json:"port,omitempty"`
+
+	// ServerName is required when Type is set to TLS or QUIC. It is the
+	// name used both for TLS/QUIC server certificate verification and as
+	// the SNI sent to the upstream. It is ignored for every other Type.
+	//
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+
+	// URL is required when Type is set to HTTPS. It is the full DoH query
+	// URL of the upstream, e.g. "https://dns.example.com/dns-query". It
+	// must use the https scheme. It is ignored for every other Type.
+	//
+	// +kubebuilder:validation:Pattern=`^https://`
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// Weight is used only when the enclosing group's Policy is
+	// LoadBalance. It biases random selection toward this upstream; higher
+	// values are selected more often. It must be between 1 and 100. It is
+	// rejected for every other Policy and for SystemResolvConf upstreams.
+	//
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Weight *uint32 `json:"weight,omitempty"`
+}
+
+// UpstreamResolvers defines a schema for configuring the CoreDNS forward
+// plugin in the specific case of the default (".") server.
+//
+// It has no Zones field. DNSSpec.UpstreamResolvers holds it by value,
+// making that field always present in the DNS schema, while
+// DNSSpec.PrivateResolvers holds it as an optional pointer.
+type UpstreamResolvers struct {
+	// Upstreams is a list of resolvers to forward DNS resolution to.
+	//
+	// If empty, the default is to use the upstream resolver declared in
+	// /etc/resolv.conf.
+	//
+	// +kubebuilder:validation:MaxItems=15
+	// +optional
+	Upstreams []Upstream `json:"upstreams,omitempty"`
+
+	// Policy is used to determine the order in which upstream servers are
+	// selected for querying. Default value is "Random".
+	//
+	// +optional
+	Policy ForwardingPolicy `json:"policy,omitempty"`
+
+	// BootstrapResolvers is a list of plain-DNS upstreams used solely to
+	// resolve the hostnames of encrypted (TLS, HTTPS, QUIC) upstreams
+	// before they can be dialed. Entries here must not themselves be
+	// encrypted upstreams.
+	//
+	// +kubebuilder:validation:MaxItems=15
+	// +optional
+	BootstrapResolvers []Upstream `json:"bootstrapResolvers,omitempty"`
+
+	// DomainResolvers is an ordered list of domain-scoped resolver groups,
+	// used to route queries for specific domains to their own upstreams
+	// and policy (split-horizon forwarding) instead of the top-level
+	// Upstreams. The group whose Domains entry is the most specific
+	// matching suffix of the query name wins; a group whose Domains
+	// includes "." matches every name and acts as the default for names
+	// that no more specific group matches, in place of the top-level
+	// Upstreams.
+	//
+	// +kubebuilder:validation:MaxItems=15
+	// +optional
+	DomainResolvers []DomainUpstreams `json:"domainResolvers,omitempty"`
+}
+
+// DomainUpstreams defines the resolvers used for queries whose name falls
+// under one of Domains.
+type DomainUpstreams struct {
+	// Domains is the list of domain suffixes handled by this group. "."
+	// matches the DNS root, i.e. every name, and is used to declare a
+	// default group. Subdomain matches are suffix-based: "example.com."
+	// matches "example.com" and "foo.example.com" but not
+	// "notexample.com". Reverse-DNS zones such as "10.in-addr.arpa." are
+	// also valid entries. A given domain may only appear in one group.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=15
+	Domains []string `json:"domains"`
+
+	// Upstreams is a list of resolvers to forward DNS resolution to for
+	// names matching Domains.
+	//
+	// +kubebuilder:validation:MaxItems=15
+	// +optional
+	Upstreams []Upstream `json:"upstreams,omitempty"`
+
+	// Policy is used to determine the order in which upstream servers are
+	// selected for querying. Default value is "Random".
+	//
+	// +optional
+	Policy ForwardingPolicy `json:"policy,omitempty"`
+}
+
+// ManagementState indicates whether and how an operator should manage its
+// resources.
+type ManagementState string
+
+// Toleration is a simplified form of the corev1.Toleration used by DNS
+// node placement.
+type Toleration struct {
+	Key      string `json:"key,omitempty"`
+	Operator string `json:"operator,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Effect   string `json:"effect,omitempty"`
+}
+
+// DNSStatus defines the observed status of the DNS.
+type DNSStatus struct {
+	// clusterIP is the service IP through which this DNS is made
+	// available.
+	//
+	// In the case of the default DNS, this will be a well known address
+	// that is used as the default nameserver for pods that are using the
+	// default ClusterFirst DNS policy.
+	//
+	// +optional
+	ClusterIP string `json:"clusterIP,omitempty"`
+
+	// clusterDomain is the local cluster DNS domain suffix for DNS
+	// resolution of cluster-local resources.
+	//
+	// +optional
+	ClusterDomain string `json:"clusterDomain,omitempty"`
+
+	// conditions provide information about the state of the DNS on the
+	// cluster.
+	//
+	// +optional
+	Conditions []OperatorCondition `json:"conditions,omitempty"`
+
+	// upstreamResolverLatency reports the most recently observed latency
+	// per upstream, keyed by address or URL. The DNS operator uses this to
+	// bias LoadBalance selection toward historically faster upstreams, and
+	// publishes it as a Prometheus gauge so that it can be scraped
+	// directly instead of parsed out of CoreDNS logs.
+	//
+	// +optional
+	UpstreamResolverLatency []UpstreamLatency `json:"upstreamResolverLatency,omitempty"`
+}
+
+// UpstreamLatency is the most recently observed response latency for a
+// single upstream.
+type UpstreamLatency struct {
+	// Upstream identifies the upstream this observation is for, either its
+	// Address or, for HTTPS upstreams, its URL.
+	Upstream string `json:"upstream"`
+
+	// LatencyMilliseconds is the most recently observed round-trip latency
+	// for Upstream, in milliseconds.
+	LatencyMilliseconds int64 `json:"latencyMilliseconds"`
+}
+
+// OperatorCondition is just the standard condition fields.
+type OperatorCondition struct {
+	Type               string      `json:"type"`
+	Status             string      `json:"status"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	Reason             string      `json:"reason,omitempty"`
+	Message            string      `json:"message,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DNSList contains a list of DNS.
+type DNSList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNS `json:"items"`
+}
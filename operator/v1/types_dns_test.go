@@ -15,6 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apiserver/pkg/storage/etcd3/testserver"
+	"k8s.io/utils/ptr"
 )
 
 // testserver uses its own pkgPath in order to use it
@@ -203,7 +204,6 @@ func TestDNSSchema(t *testing.T) {
 				},
 			},
 			expectedErrorMessage: "",
-			expectedPort:         53,
 			expectedAddress:      "1.2.3.4",
 			expectedType:         NetworkResolverType,
 		},
@@ -335,6 +335,995 @@ func TestDNSSchema(t *testing.T) {
 			expectedErrorMessage: "\"spec.upstreamresolvers.upstreams\" must validate at least one schema (anyOf)",
 			expectedType:         SystemResolveConfType,
 		},
+		{
+			name: "Dns spec with TLS upstream passes",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{
+								Type:       TLSResolverType,
+								Address:    "1.2.3.4",
+								ServerName: "dns.example.com",
+							},
+						},
+						Policy: RoundRobinForwardingPolicy,
+					},
+				},
+			},
+			expectedErrorMessage: "",
+			expectedType:         TLSResolverType,
+		},
+		{
+			name: "Dns spec with QUIC upstream missing ServerName fails",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{
+								Type:    QUICResolverType,
+								Address: "1.2.3.4",
+							},
+						},
+						Policy: RoundRobinForwardingPolicy,
+					},
+				},
+			},
+			expectedErrorMessage: "\"spec.upstreamresolvers.upstreams\" must validate at least one schema (anyOf)",
+		},
+		{
+			name: "Dns spec with HTTPS upstream passes",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{
+								Type: HTTPSResolverType,
+								URL:  "https://dns.example.com/dns-query",
+							},
+						},
+						Policy: RoundRobinForwardingPolicy,
+					},
+				},
+			},
+			expectedErrorMessage: "",
+			expectedType:         HTTPSResolverType,
+		},
+		{
+			name: "Dns spec with HTTPS upstream with non-HTTPS URL fails",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{
+								Type: HTTPSResolverType,
+								URL:  "dns://dns.example.com/dns-query",
+							},
+						},
+						Policy: RoundRobinForwardingPolicy,
+					},
+				},
+			},
+			expectedErrorMessage: "url in body should match '^https://'",
+		},
+		{
+			name: "Dns spec with plain bootstrap resolver passes",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{
+								Type: HTTPSResolverType,
+								URL:  "https://dns.example.com/dns-query",
+							},
+						},
+						BootstrapResolvers: []Upstream{
+							{
+								Type:    NetworkResolverType,
+								Address: "1.2.3.4",
+							},
+						},
+						Policy: RoundRobinForwardingPolicy,
+					},
+				},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			name: "Dns spec with encrypted bootstrap resolver fails",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{
+								Type: HTTPSResolverType,
+								URL:  "https://dns.example.com/dns-query",
+							},
+						},
+						BootstrapResolvers: []Upstream{
+							{
+								Type:       TLSResolverType,
+								Address:    "1.2.3.4",
+								ServerName: "dns.example.com",
+							},
+						},
+						Policy: RoundRobinForwardingPolicy,
+					},
+				},
+			},
+			expectedErrorMessage: "\"spec.upstreamresolvers.bootstrapresolvers\" must validate at least one schema (anyOf)",
+		},
+		{
+			name: "Dns spec with overlapping domainResolvers suffixes passes",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{
+								Type: SystemResolveConfType,
+							},
+						},
+						Policy: RoundRobinForwardingPolicy,
+						DomainResolvers: []DomainUpstreams{
+							{
+								Domains: []string{"example.com."},
+								Upstreams: []Upstream{
+									{Type: NetworkResolverType, Address: "1.2.3.4"},
+								},
+								Policy: RoundRobinForwardingPolicy,
+							},
+							{
+								Domains: []string{"svc.example.com."},
+								Upstreams: []Upstream{
+									{Type: NetworkResolverType, Address: "1.2.3.5"},
+								},
+								Policy: RoundRobinForwardingPolicy,
+							},
+						},
+					},
+				},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			name: "Dns spec with duplicate domainResolvers domain fails",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{
+								Type: SystemResolveConfType,
+							},
+						},
+						Policy: RoundRobinForwardingPolicy,
+						DomainResolvers: []DomainUpstreams{
+							{
+								Domains: []string{"example.com."},
+								Upstreams: []Upstream{
+									{Type: NetworkResolverType, Address: "1.2.3.4"},
+								},
+							},
+							{
+								Domains: []string{"example.com."},
+								Upstreams: []Upstream{
+									{Type: NetworkResolverType, Address: "1.2.3.5"},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrorMessage: "each domain may only appear in one domainResolvers group",
+		},
+		{
+			name: "Dns spec with a fully duplicated domainResolvers group fails",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{
+								Type: SystemResolveConfType,
+							},
+						},
+						Policy: RoundRobinForwardingPolicy,
+						DomainResolvers: []DomainUpstreams{
+							{
+								Domains: []string{"example.com."},
+								Upstreams: []Upstream{
+									{Type: NetworkResolverType, Address: "1.2.3.4"},
+								},
+								Policy: RoundRobinForwardingPolicy,
+							},
+							{
+								Domains: []string{"example.com."},
+								Upstreams: []Upstream{
+									{Type: NetworkResolverType, Address: "1.2.3.4"},
+								},
+								Policy: RoundRobinForwardingPolicy,
+							},
+						},
+					},
+				},
+			},
+			expectedErrorMessage: "each domain may only appear in one domainResolvers group",
+		},
+		{
+			name: "Dns spec with invalid domainResolvers domain name fails",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{
+								Type: SystemResolveConfType,
+							},
+						},
+						Policy: RoundRobinForwardingPolicy,
+						DomainResolvers: []DomainUpstreams{
+							{
+								Domains: []string{"not a domain"},
+								Upstreams: []Upstream{
+									{Type: NetworkResolverType, Address: "1.2.3.4"},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrorMessage: "domains in body should match",
+		},
+		{
+			name: "Dns spec with root domainResolvers default group passes",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{
+								Type: SystemResolveConfType,
+							},
+						},
+						Policy: RoundRobinForwardingPolicy,
+						DomainResolvers: []DomainUpstreams{
+							{
+								Domains: []string{"."},
+								Upstreams: []Upstream{
+									{Type: NetworkResolverType, Address: "1.2.3.4"},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			name: "Dns spec with reverse-DNS domainResolvers zone passes",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{
+								Type: SystemResolveConfType,
+							},
+						},
+						Policy: RoundRobinForwardingPolicy,
+						DomainResolvers: []DomainUpstreams{
+							{
+								Domains: []string{"10.in-addr.arpa."},
+								Upstreams: []Upstream{
+									{Type: NetworkResolverType, Address: "1.2.3.4"},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			name: "Dns spec with empty domainResolvers Domains fails",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{
+								Type: SystemResolveConfType,
+							},
+						},
+						Policy: RoundRobinForwardingPolicy,
+						DomainResolvers: []DomainUpstreams{
+							{
+								Domains:   []string{},
+								Upstreams: []Upstream{{Type: NetworkResolverType, Address: "1.2.3.4"}},
+							},
+						},
+					},
+				},
+			},
+			expectedErrorMessage: "domains in body should have at least 1 items",
+		},
+		{
+			name: "Dns spec with private resolvers and valid IPv4/IPv6 CIDRs passes",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{Type: SystemResolveConfType},
+						},
+						Policy: RoundRobinForwardingPolicy,
+					},
+					ResolvePrivateEnabled: ptr.To(true),
+					PrivateResolvers: &UpstreamResolvers{
+						Upstreams: []Upstream{
+							{Type: NetworkResolverType, Address: "10.0.0.1"},
+						},
+						Policy: RoundRobinForwardingPolicy,
+					},
+					PrivateSubnets: []string{"10.0.0.0/8", "fe80::/10"},
+				},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			name: "Dns spec with invalid private subnet CIDR fails",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{Type: SystemResolveConfType},
+						},
+						Policy: RoundRobinForwardingPolicy,
+					},
+					ResolvePrivateEnabled: ptr.To(true),
+					PrivateSubnets:        []string{"not-a-cidr"},
+				},
+			},
+			expectedErrorMessage: "privateSubnets entries must be valid CIDR notation",
+		},
+		{
+			name: "Dns spec with out-of-range private subnet prefix length fails",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{Type: SystemResolveConfType},
+						},
+						Policy: RoundRobinForwardingPolicy,
+					},
+					ResolvePrivateEnabled: ptr.To(true),
+					PrivateSubnets:        []string{"10.0.0.0/40"},
+				},
+			},
+			expectedErrorMessage: "privateSubnets entries must be valid CIDR notation",
+		},
+		{
+			name: "Dns spec with out-of-range private subnet octet fails",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{Type: SystemResolveConfType},
+						},
+						Policy: RoundRobinForwardingPolicy,
+					},
+					ResolvePrivateEnabled: ptr.To(true),
+					PrivateSubnets:        []string{"999.999.999.999/24"},
+				},
+			},
+			expectedErrorMessage: "privateSubnets entries must be valid CIDR notation",
+		},
+		{
+			name: "Dns spec with resolvePrivateEnabled but no private resolvers or subnets fails",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{Type: SystemResolveConfType},
+						},
+						Policy: RoundRobinForwardingPolicy,
+					},
+					ResolvePrivateEnabled: ptr.To(true),
+				},
+			},
+			expectedErrorMessage: "\"spec\" must validate at least one schema (anyOf)",
+		},
+		{
+			name: "Dns spec with IPv6 link-local private subnet and System upstream passes",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{Type: SystemResolveConfType},
+						},
+						Policy: RoundRobinForwardingPolicy,
+					},
+					ResolvePrivateEnabled: ptr.To(true),
+					PrivateSubnets:        []string{"fe80::/10"},
+				},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			name: "Dns spec with LoadBalance policy and weighted upstreams passes",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{Type: NetworkResolverType, Address: "1.2.3.4", Weight: ptr.To(uint32(50))},
+							{Type: NetworkResolverType, Address: "1.2.3.5", Weight: ptr.To(uint32(100))},
+						},
+						Policy: LoadBalanceForwardingPolicy,
+					},
+				},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			name: "Dns spec with Fastest policy passes",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{Type: NetworkResolverType, Address: "1.2.3.4"},
+							{Type: NetworkResolverType, Address: "1.2.3.5"},
+						},
+						Policy: FastestForwardingPolicy,
+					},
+				},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			name: "Dns spec with Parallel policy passes",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{Type: NetworkResolverType, Address: "1.2.3.4"},
+							{Type: NetworkResolverType, Address: "1.2.3.5"},
+						},
+						Policy: ParallelForwardingPolicy,
+					},
+				},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			name: "Dns spec with Weight out of bounds fails",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{Type: NetworkResolverType, Address: "1.2.3.4", Weight: ptr.To(uint32(101))},
+						},
+						Policy: LoadBalanceForwardingPolicy,
+					},
+				},
+			},
+			expectedErrorMessage: "weight in body should be less than or equal to 100",
+		},
+		{
+			name: "Dns spec with Weight set under RoundRobin policy fails",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{Type: NetworkResolverType, Address: "1.2.3.4", Weight: ptr.To(uint32(50))},
+						},
+						Policy: RoundRobinForwardingPolicy,
+					},
+				},
+			},
+			expectedErrorMessage: "weight may only be set on upstreams when policy is LoadBalance",
+		},
+		{
+			name: "Dns spec with Weight on SystemResolvConf upstream fails",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{Type: SystemResolveConfType, Weight: ptr.To(uint32(50))},
+						},
+						Policy: LoadBalanceForwardingPolicy,
+					},
+				},
+			},
+			expectedErrorMessage: "\"spec.upstreamresolvers.upstreams\" must validate at least one schema (anyOf)",
+		},
+		{
+			name: "Dns spec with LoadBalance policy and weighted upstreams in a domainResolvers group passes",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{Type: SystemResolveConfType},
+						},
+						Policy: RoundRobinForwardingPolicy,
+						DomainResolvers: []DomainUpstreams{
+							{
+								Domains: []string{"example.com."},
+								Upstreams: []Upstream{
+									{Type: NetworkResolverType, Address: "1.2.3.4", Weight: ptr.To(uint32(50))},
+									{Type: NetworkResolverType, Address: "1.2.3.5", Weight: ptr.To(uint32(100))},
+								},
+								Policy: LoadBalanceForwardingPolicy,
+							},
+						},
+					},
+				},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			name: "Dns spec with Weight set under RoundRobin policy in a domainResolvers group fails",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{Type: SystemResolveConfType},
+						},
+						Policy: RoundRobinForwardingPolicy,
+						DomainResolvers: []DomainUpstreams{
+							{
+								Domains: []string{"example.com."},
+								Upstreams: []Upstream{
+									{Type: NetworkResolverType, Address: "1.2.3.4", Weight: ptr.To(uint32(50))},
+								},
+								Policy: RoundRobinForwardingPolicy,
+							},
+						},
+					},
+				},
+			},
+			expectedErrorMessage: "weight may only be set on upstreams when policy is LoadBalance",
+		},
+		{
+			name: "Dns spec with valid caching policy passes",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{Type: SystemResolveConfType},
+						},
+						Policy: RoundRobinForwardingPolicy,
+					},
+					Caching: &CachingPolicy{
+						MinTTLSeconds:         ptr.To(uint32(30)),
+						MaxTTLSeconds:         ptr.To(uint32(3600)),
+						MaxNegativeTTLSeconds: ptr.To(uint32(60)),
+						SizeMiB:               ptr.To(uint32(64)),
+						Optimistic:            ptr.To(true),
+					},
+				},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			name: "Dns spec with inverted min/max TTL fails",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{Type: SystemResolveConfType},
+						},
+						Policy: RoundRobinForwardingPolicy,
+					},
+					Caching: &CachingPolicy{
+						MinTTLSeconds: ptr.To(uint32(3600)),
+						MaxTTLSeconds: ptr.To(uint32(30)),
+					},
+				},
+			},
+			expectedErrorMessage: "minTTLSeconds must be less than or equal to maxTTLSeconds",
+		},
+		{
+			name: "Dns spec with optimistic caching and unset max TTL passes",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{Type: SystemResolveConfType},
+						},
+						Policy: RoundRobinForwardingPolicy,
+					},
+					Caching: &CachingPolicy{
+						Optimistic: ptr.To(true),
+					},
+				},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			name: "Dns spec with optimistic caching and zero max TTL fails",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{Type: SystemResolveConfType},
+						},
+						Policy: RoundRobinForwardingPolicy,
+					},
+					Caching: &CachingPolicy{
+						MaxTTLSeconds: ptr.To(uint32(0)),
+						Optimistic:    ptr.To(true),
+					},
+				},
+			},
+			expectedErrorMessage: "optimistic may only be true when maxTTLSeconds is non-zero",
+		},
+		{
+			name: "Dns spec with oversized TTL fails",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{Type: SystemResolveConfType},
+						},
+						Policy: RoundRobinForwardingPolicy,
+					},
+					Caching: &CachingPolicy{
+						MaxTTLSeconds: ptr.To(uint32(100000)),
+					},
+				},
+			},
+			expectedErrorMessage: "maxTTLSeconds in body should be less than or equal to 86400",
+		},
+		{
+			name: "Dns spec with protection happy path passes",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{Type: NetworkResolverType, Address: "1.2.3.4"},
+						},
+						Policy: RoundRobinForwardingPolicy,
+					},
+					Protection: &ProtectionSpec{
+						RateLimitQPS:         ptr.To(uint32(100)),
+						RateLimitSubnetLenV4: ptr.To(uint32(24)),
+						RateLimitSubnetLenV6: ptr.To(uint32(56)),
+						EDNSClientSubnet:     ptr.To(true),
+						DNSSECValidation:     ptr.To(true),
+						RefuseANY:            ptr.To(true),
+					},
+				},
+			},
+			expectedErrorMessage: "",
+		},
+		{
+			name: "Dns spec with out-of-range rateLimitSubnetLenV4 fails",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{Type: NetworkResolverType, Address: "1.2.3.4"},
+						},
+						Policy: RoundRobinForwardingPolicy,
+					},
+					Protection: &ProtectionSpec{
+						RateLimitSubnetLenV4: ptr.To(uint32(33)),
+					},
+				},
+			},
+			expectedErrorMessage: "rateLimitSubnetLenV4 in body should be less than or equal to 32",
+		},
+		{
+			name: "Dns spec with out-of-range rateLimitSubnetLenV6 fails",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{Type: NetworkResolverType, Address: "1.2.3.4"},
+						},
+						Policy: RoundRobinForwardingPolicy,
+					},
+					Protection: &ProtectionSpec{
+						RateLimitSubnetLenV6: ptr.To(uint32(129)),
+					},
+				},
+			},
+			expectedErrorMessage: "rateLimitSubnetLenV6 in body should be less than or equal to 128",
+		},
+		{
+			name: "Dns spec with EDNS Client Subnet and SystemResolvConf upstream fails",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{Type: SystemResolveConfType},
+						},
+						Policy: RoundRobinForwardingPolicy,
+					},
+					Protection: &ProtectionSpec{
+						EDNSClientSubnet: ptr.To(true),
+					},
+				},
+			},
+			expectedErrorMessage: "protection.ednsClientSubnet cannot be enabled when any configured upstream, including those in domainResolvers or privateResolvers, is SystemResolvConf",
+		},
+		{
+			name: "Dns spec with EDNS Client Subnet and SystemResolvConf upstream tucked into a domainResolvers group fails",
+			dns: &DNS{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DNS",
+					APIVersion: "operator.openshift.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: DNSSpec{
+					UpstreamResolvers: UpstreamResolvers{
+						Upstreams: []Upstream{
+							{Type: NetworkResolverType, Address: "1.2.3.4"},
+						},
+						Policy: RoundRobinForwardingPolicy,
+						DomainResolvers: []DomainUpstreams{
+							{
+								Domains: []string{"example.com."},
+								Upstreams: []Upstream{
+									{Type: SystemResolveConfType},
+								},
+							},
+						},
+					},
+					Protection: &ProtectionSpec{
+						EDNSClientSubnet: ptr.To(true),
+					},
+				},
+			},
+			expectedErrorMessage: "protection.ednsClientSubnet cannot be enabled when any configured upstream, including those in domainResolvers or privateResolvers, is SystemResolvConf",
+		},
 	}
 
 	for _, tc := range testCases {